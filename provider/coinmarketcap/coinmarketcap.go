@@ -0,0 +1,218 @@
+// Package coinmarketcap implements provider.PriceProvider against the CoinMarketCap API
+package coinmarketcap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/superoo7/go-gecko/v3/types"
+)
+
+var baseURL = "https://pro-api.coinmarketcap.com/v1"
+
+// Client is a provider.PriceProvider backed by the CoinMarketCap API.
+type Client struct {
+	httpClient *http.Client
+	apiKey     string
+	url        string
+}
+
+// NewClient creates a new CoinMarketCap client
+func NewClient(httpClient *http.Client, apiKey string) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{httpClient: httpClient, apiKey: apiKey, url: baseURL}
+}
+
+func (c *Client) makeReq(ctx context.Context, path string, params url.Values) ([]byte, error) {
+	u := fmt.Sprintf("%s/%s?%s", c.url, path, params.Encode())
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-CMC_PRO_API_KEY", c.apiKey)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("coinmarketcap: status %d: %s", resp.StatusCode, body)
+	}
+	return body, nil
+}
+
+// Ping checks API key validity via /key/info.
+func (c *Client) Ping(ctx context.Context) (*types.Ping, error) {
+	if _, err := c.makeReq(ctx, "key/info", url.Values{}); err != nil {
+		return nil, err
+	}
+	return &types.Ping{}, nil
+}
+
+// SimplePrice queries /cryptocurrency/quotes/latest. ids are CMC symbols (e.g. "BTC"), not CoinGecko slugs.
+func (c *Client) SimplePrice(ctx context.Context, ids []string, vsCurrencies []string) (*map[string]map[string]float32, error) {
+	params := url.Values{}
+	params.Set("symbol", strings.ToUpper(strings.Join(ids, ",")))
+	params.Set("convert", strings.ToUpper(strings.Join(vsCurrencies, ",")))
+
+	resp, err := c.makeReq(ctx, "cryptocurrency/quotes/latest", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var payload struct {
+		Data map[string]struct {
+			Quote map[string]struct {
+				Price float32 `json:"price"`
+			} `json:"quote"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(resp, &payload); err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]map[string]float32, len(payload.Data))
+	for symbol, d := range payload.Data {
+		id := strings.ToLower(symbol)
+		result[id] = make(map[string]float32, len(d.Quote))
+		for currency, q := range d.Quote {
+			result[id][strings.ToLower(currency)] = q.Price
+		}
+	}
+	return &result, nil
+}
+
+// CoinsMarket queries /cryptocurrency/listings/latest.
+func (c *Client) CoinsMarket(ctx context.Context, vsCurrency string, ids []string, order string, perPage int, page int, sparkline bool, priceChangePercentage []string) (*types.CoinsMarket, error) {
+	params := url.Values{}
+	params.Set("convert", strings.ToUpper(vsCurrency))
+	if len(ids) != 0 {
+		params.Set("symbol", strings.ToUpper(strings.Join(ids, ",")))
+	}
+	if perPage > 0 {
+		params.Set("limit", fmt.Sprintf("%d", perPage))
+	}
+
+	resp, err := c.makeReq(ctx, "cryptocurrency/listings/latest", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var payload struct {
+		Data []struct {
+			Slug   string `json:"slug"`
+			Symbol string `json:"symbol"`
+			Name   string `json:"name"`
+			Quote  map[string]struct {
+				Price     float64 `json:"price"`
+				MarketCap float64 `json:"market_cap"`
+			} `json:"quote"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(resp, &payload); err != nil {
+		return nil, err
+	}
+
+	market := make(types.CoinsMarket, 0, len(payload.Data))
+	for _, item := range payload.Data {
+		q := item.Quote[strings.ToUpper(vsCurrency)]
+		market = append(market, types.CoinsMarketItem{
+			ID:           item.Slug,
+			Symbol:       strings.ToLower(item.Symbol),
+			Name:         item.Name,
+			CurrentPrice: q.Price,
+			MarketCap:    q.MarketCap,
+		})
+	}
+	return &market, nil
+}
+
+// CoinsIDMarketChart queries /cryptocurrency/quotes/historical. id is a CMC symbol.
+// days is sent as CMC's count parameter, the number of data points at the
+// API's default interval, not a number of days.
+func (c *Client) CoinsIDMarketChart(ctx context.Context, id string, vsCurrency string, days string) (*types.CoinsIDMarketChart, error) {
+	params := url.Values{}
+	params.Set("symbol", strings.ToUpper(id))
+	params.Set("convert", strings.ToUpper(vsCurrency))
+	params.Set("count", days)
+
+	resp, err := c.makeReq(ctx, "cryptocurrency/quotes/historical", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var payload struct {
+		Data struct {
+			Quotes []struct {
+				Timestamp int64 `json:"timestamp"`
+				Quote     map[string]struct {
+					Price     float64 `json:"price"`
+					MarketCap float64 `json:"market_cap"`
+					Volume24h float64 `json:"volume_24h"`
+				} `json:"quote"`
+			} `json:"quotes"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(resp, &payload); err != nil {
+		return nil, err
+	}
+
+	currency := strings.ToUpper(vsCurrency)
+	chart := &types.CoinsIDMarketChart{
+		Prices:       make([][]float64, 0, len(payload.Data.Quotes)),
+		MarketCaps:   make([][]float64, 0, len(payload.Data.Quotes)),
+		TotalVolumes: make([][]float64, 0, len(payload.Data.Quotes)),
+	}
+	for _, q := range payload.Data.Quotes {
+		quote := q.Quote[currency]
+		ts := float64(q.Timestamp)
+		chart.Prices = append(chart.Prices, []float64{ts, quote.Price})
+		chart.MarketCaps = append(chart.MarketCaps, []float64{ts, quote.MarketCap})
+		chart.TotalVolumes = append(chart.TotalVolumes, []float64{ts, quote.Volume24h})
+	}
+	return chart, nil
+}
+
+// ExchangeRates approximates /exchange_rates via /tools/price-conversion of 1 BTC.
+func (c *Client) ExchangeRates(ctx context.Context) (*types.ExchangeRatesItem, error) {
+	currencies := []string{"USD", "EUR", "BTC", "ETH"}
+	params := url.Values{}
+	params.Set("amount", "1")
+	params.Set("symbol", "BTC")
+	params.Set("convert", strings.Join(currencies, ","))
+
+	resp, err := c.makeReq(ctx, "tools/price-conversion", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var payload struct {
+		Data struct {
+			Quote map[string]struct {
+				Price float64 `json:"price"`
+			} `json:"quote"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(resp, &payload); err != nil {
+		return nil, err
+	}
+
+	rates := make(types.ExchangeRatesItem, len(payload.Data.Quote))
+	for currency, q := range payload.Data.Quote {
+		rates[strings.ToLower(currency)] = types.ExchangeRate{Value: q.Price}
+	}
+	return &rates, nil
+}
@@ -0,0 +1,59 @@
+package coinmarketcap
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSimplePrice(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":{"BTC":{"quote":{"USD":{"price":50000.5}}}}}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(nil, "test-key")
+	c.url = srv.URL
+
+	got, err := c.SimplePrice(context.Background(), []string{"BTC"}, []string{"USD"})
+	if err != nil {
+		t.Fatalf("SimplePrice: %v", err)
+	}
+	if (*got)["btc"]["usd"] != 50000.5 {
+		t.Fatalf("expected translated lowercase id/currency keys, got %v", *got)
+	}
+}
+
+func TestCoinsMarket(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":[{"slug":"bitcoin","symbol":"BTC","name":"Bitcoin","quote":{"USD":{"price":50000.5,"market_cap":1000}}}]}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(nil, "test-key")
+	c.url = srv.URL
+
+	market, err := c.CoinsMarket(context.Background(), "usd", []string{"BTC"}, "", 0, 0, false, nil)
+	if err != nil {
+		t.Fatalf("CoinsMarket: %v", err)
+	}
+	if len(*market) != 1 || (*market)[0].ID != "bitcoin" || (*market)[0].CurrentPrice != 50000.5 {
+		t.Fatalf("expected one translated market item, got %+v", *market)
+	}
+}
+
+func TestPingPropagatesErrorOnNon200(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"status":{"error_message":"invalid key"}}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(nil, "bad-key")
+	c.url = srv.URL
+
+	if _, err := c.Ping(context.Background()); err == nil {
+		t.Fatal("expected an error for a non-200 response")
+	}
+}
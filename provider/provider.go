@@ -0,0 +1,105 @@
+// Package provider defines a common interface over price data sources
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/superoo7/go-gecko/v3/types"
+)
+
+// PriceProvider is the read surface shared by coingecko.Client and other backends
+type PriceProvider interface {
+	Ping(ctx context.Context) (*types.Ping, error)
+	SimplePrice(ctx context.Context, ids []string, vsCurrencies []string) (*map[string]map[string]float32, error)
+	CoinsMarket(ctx context.Context, vsCurrency string, ids []string, order string, perPage int, page int, sparkline bool, priceChangePercentage []string) (*types.CoinsMarket, error)
+	CoinsIDMarketChart(ctx context.Context, id string, vsCurrency string, days string) (*types.CoinsIDMarketChart, error)
+	ExchangeRates(ctx context.Context) (*types.ExchangeRatesItem, error)
+}
+
+// fallbackProvider tries each provider in order until one succeeds
+type fallbackProvider struct {
+	providers []PriceProvider
+}
+
+// Fallback composes providers, trying each in turn on error or empty result
+func Fallback(primary PriceProvider, secondary ...PriceProvider) PriceProvider {
+	return &fallbackProvider{providers: append([]PriceProvider{primary}, secondary...)}
+}
+
+func (f *fallbackProvider) Ping(ctx context.Context) (*types.Ping, error) {
+	var lastErr error
+	for _, p := range f.providers {
+		data, err := p.Ping(ctx)
+		if err == nil && data != nil {
+			return data, nil
+		}
+		if err != nil {
+			lastErr = err
+		}
+	}
+	return nil, noResultErr(lastErr)
+}
+
+func (f *fallbackProvider) SimplePrice(ctx context.Context, ids []string, vsCurrencies []string) (*map[string]map[string]float32, error) {
+	var lastErr error
+	for _, p := range f.providers {
+		data, err := p.SimplePrice(ctx, ids, vsCurrencies)
+		if err == nil && data != nil && len(*data) != 0 {
+			return data, nil
+		}
+		if err != nil {
+			lastErr = err
+		}
+	}
+	return nil, noResultErr(lastErr)
+}
+
+func (f *fallbackProvider) CoinsMarket(ctx context.Context, vsCurrency string, ids []string, order string, perPage int, page int, sparkline bool, priceChangePercentage []string) (*types.CoinsMarket, error) {
+	var lastErr error
+	for _, p := range f.providers {
+		data, err := p.CoinsMarket(ctx, vsCurrency, ids, order, perPage, page, sparkline, priceChangePercentage)
+		if err == nil && data != nil && len(*data) != 0 {
+			return data, nil
+		}
+		if err != nil {
+			lastErr = err
+		}
+	}
+	return nil, noResultErr(lastErr)
+}
+
+func (f *fallbackProvider) CoinsIDMarketChart(ctx context.Context, id string, vsCurrency string, days string) (*types.CoinsIDMarketChart, error) {
+	var lastErr error
+	for _, p := range f.providers {
+		data, err := p.CoinsIDMarketChart(ctx, id, vsCurrency, days)
+		if err == nil && data != nil && len(data.Prices) != 0 {
+			return data, nil
+		}
+		if err != nil {
+			lastErr = err
+		}
+	}
+	return nil, noResultErr(lastErr)
+}
+
+func (f *fallbackProvider) ExchangeRates(ctx context.Context) (*types.ExchangeRatesItem, error) {
+	var lastErr error
+	for _, p := range f.providers {
+		data, err := p.ExchangeRates(ctx)
+		if err == nil && data != nil && len(*data) != 0 {
+			return data, nil
+		}
+		if err != nil {
+			lastErr = err
+		}
+	}
+	return nil, noResultErr(lastErr)
+}
+
+func noResultErr(lastErr error) error {
+	if lastErr != nil {
+		return lastErr
+	}
+	return fmt.Errorf("provider: all providers returned an empty result")
+}
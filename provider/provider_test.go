@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/superoo7/go-gecko/v3/types"
+)
+
+// stubProvider is a minimal PriceProvider for exercising Fallback.
+type stubProvider struct {
+	simplePriceResp *map[string]map[string]float32
+	simplePriceErr  error
+}
+
+func (s *stubProvider) Ping(ctx context.Context) (*types.Ping, error) { return nil, nil }
+
+func (s *stubProvider) SimplePrice(ctx context.Context, ids []string, vsCurrencies []string) (*map[string]map[string]float32, error) {
+	return s.simplePriceResp, s.simplePriceErr
+}
+
+func (s *stubProvider) CoinsMarket(ctx context.Context, vsCurrency string, ids []string, order string, perPage int, page int, sparkline bool, priceChangePercentage []string) (*types.CoinsMarket, error) {
+	return nil, nil
+}
+
+func (s *stubProvider) CoinsIDMarketChart(ctx context.Context, id string, vsCurrency string, days string) (*types.CoinsIDMarketChart, error) {
+	return nil, nil
+}
+
+func (s *stubProvider) ExchangeRates(ctx context.Context) (*types.ExchangeRatesItem, error) {
+	return nil, nil
+}
+
+func TestFallbackSkipsEmptyResult(t *testing.T) {
+	empty := map[string]map[string]float32{}
+	primary := &stubProvider{simplePriceResp: &empty}
+	want := map[string]map[string]float32{"bitcoin": {"usd": 1}}
+	secondary := &stubProvider{simplePriceResp: &want}
+
+	got, err := Fallback(primary, secondary).SimplePrice(context.Background(), []string{"bitcoin"}, []string{"usd"})
+	if err != nil {
+		t.Fatalf("SimplePrice: %v", err)
+	}
+	if (*got)["bitcoin"]["usd"] != 1 {
+		t.Fatalf("expected result from secondary, got %v", *got)
+	}
+}
+
+func TestFallbackTriesSecondaryOnError(t *testing.T) {
+	primary := &stubProvider{simplePriceErr: errors.New("primary down")}
+	want := map[string]map[string]float32{"bitcoin": {"usd": 2}}
+	secondary := &stubProvider{simplePriceResp: &want}
+
+	got, err := Fallback(primary, secondary).SimplePrice(context.Background(), []string{"bitcoin"}, []string{"usd"})
+	if err != nil {
+		t.Fatalf("SimplePrice: %v", err)
+	}
+	if (*got)["bitcoin"]["usd"] != 2 {
+		t.Fatalf("expected result from secondary, got %v", *got)
+	}
+}
+
+func TestFallbackReturnsErrorWhenAllFail(t *testing.T) {
+	primary := &stubProvider{simplePriceErr: errors.New("primary down")}
+	secondary := &stubProvider{simplePriceErr: errors.New("secondary down")}
+
+	if _, err := Fallback(primary, secondary).SimplePrice(context.Background(), []string{"bitcoin"}, []string{"usd"}); err == nil {
+		t.Fatal("expected an error when every provider fails")
+	}
+}
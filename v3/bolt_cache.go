@@ -0,0 +1,67 @@
+package coingecko
+
+import (
+	"context"
+	"encoding/json"
+
+	"go.etcd.io/bbolt"
+)
+
+var boltCacheBucket = []byte("gecko-cache")
+
+// BoltCache is a Cache backed by a single bbolt database file, a better fit
+// than FileCache for long-running processes with a large number of keys.
+type BoltCache struct {
+	db *bbolt.DB
+}
+
+// NewBoltCache opens (creating if needed) a bbolt database at path.
+func NewBoltCache(path string) (*BoltCache, error) {
+	db, err := bbolt.Open(path, 0o600, nil)
+	if err != nil {
+		return nil, err
+	}
+	err = db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(boltCacheBucket)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &BoltCache{db: db}, nil
+}
+
+func (b *BoltCache) Get(ctx context.Context, key string) (*CacheEntry, bool) {
+	var entry CacheEntry
+	found := false
+	_ = b.db.View(func(tx *bbolt.Tx) error {
+		v := tx.Bucket(boltCacheBucket).Get([]byte(key))
+		if v == nil {
+			return nil
+		}
+		if err := json.Unmarshal(v, &entry); err != nil {
+			return nil
+		}
+		found = true
+		return nil
+	})
+	if !found {
+		return nil, false
+	}
+	return &entry, true
+}
+
+func (b *BoltCache) Set(ctx context.Context, key string, entry *CacheEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(boltCacheBucket).Put([]byte(key), data)
+	})
+}
+
+// Close releases the underlying bbolt database file.
+func (b *BoltCache) Close() error {
+	return b.db.Close()
+}
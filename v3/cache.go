@@ -0,0 +1,72 @@
+package coingecko
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// CacheEntry is a stored MakeReq response plus its conditional-GET validators
+type CacheEntry struct {
+	Body         []byte
+	ETag         string
+	LastModified string
+	StoredAt     time.Time
+}
+
+// Cache persists MakeReq responses keyed by the full request URL
+type Cache interface {
+	Get(ctx context.Context, key string) (*CacheEntry, bool)
+	Set(ctx context.Context, key string, entry *CacheEntry) error
+}
+
+// CachePolicy returns the TTL for a request URL; 0 disables caching, negative never expires
+type CachePolicy func(url string) time.Duration
+
+// DefaultCachePolicy caches coins/list and asset_platforms for 24h, simple/price
+// for 60s, and a /history lookup forever if its date is strictly before
+// today (CoinGecko's data for the current day can still change) else 60s.
+func DefaultCachePolicy(rawURL string) time.Duration {
+	switch {
+	case strings.Contains(rawURL, "/coins/list"), strings.Contains(rawURL, "/asset_platforms"):
+		return 24 * time.Hour
+	case strings.Contains(rawURL, "/simple/price"):
+		return 60 * time.Second
+	case strings.Contains(rawURL, "/history"):
+		if isPastHistoryDate(rawURL) {
+			return -1
+		}
+		return 60 * time.Second
+	default:
+		return 0
+	}
+}
+
+// isPastHistoryDate reports whether a /coins/{id}/history?date=dd-mm-yyyy
+// URL's date is strictly before today.
+func isPastHistoryDate(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	date, err := time.Parse("02-01-2006", u.Query().Get("date"))
+	if err != nil {
+		return false
+	}
+	y, m, d := time.Now().Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	return date.Before(today)
+}
+
+// NewClientWithCache wraps NewClientWithURL with a response Cache
+func NewClientWithCache(httpClient *http.Client, url, apiKey string, cache Cache, policy CachePolicy, opts ...ClientOption) *Client {
+	if policy == nil {
+		policy = DefaultCachePolicy
+	}
+	c := NewClientWithURL(httpClient, url, apiKey, opts...)
+	c.cache = cache
+	c.cachePolicy = policy
+	return c
+}
@@ -0,0 +1,49 @@
+package coingecko
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMakeReqServesFreshCacheWithoutNetworkCall(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Write([]byte(`{"gecko_says":"ok"}`))
+	}))
+	defer srv.Close()
+
+	cache, err := NewFileCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileCache: %v", err)
+	}
+	client := NewClientWithCache(nil, srv.URL, "", cache, nil)
+
+	url := srv.URL + "/coins/list"
+	if _, err := client.MakeReq(context.Background(), url); err != nil {
+		t.Fatalf("first MakeReq: %v", err)
+	}
+	if _, err := client.MakeReq(context.Background(), url); err != nil {
+		t.Fatalf("second MakeReq: %v", err)
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("expected the second call to be served from cache (1 upstream call), got %d", got)
+	}
+}
+
+func TestDefaultCachePolicyHistory(t *testing.T) {
+	yesterday := time.Now().AddDate(0, 0, -1).Format("02-01-2006")
+	today := time.Now().Format("02-01-2006")
+
+	if ttl := DefaultCachePolicy("https://api.coingecko.com/api/v3/coins/bitcoin/history?date=" + yesterday); ttl != -1 {
+		t.Fatalf("expected a past date to never expire, got TTL %v", ttl)
+	}
+	if ttl := DefaultCachePolicy("https://api.coingecko.com/api/v3/coins/bitcoin/history?date=" + today); ttl == -1 {
+		t.Fatal("expected today's date to use a short TTL, not cache forever")
+	}
+}
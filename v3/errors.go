@@ -0,0 +1,23 @@
+package coingecko
+
+import (
+	"fmt"
+	"time"
+)
+
+// APIError is returned by MakeReq for any non-200 response
+type APIError struct {
+	StatusCode int
+	Body       []byte
+	RetryAfter time.Duration // from the Retry-After header, 0 if none
+	Attempt    int           // zero-based retry attempt that produced this error
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("coingecko: status %d: %s", e.StatusCode, e.Body)
+}
+
+// Retryable reports whether this is a 429 or 5xx
+func (e *APIError) Retryable() bool {
+	return e.StatusCode == 429 || e.StatusCode >= 500
+}
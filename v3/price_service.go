@@ -0,0 +1,206 @@
+package coingecko
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/superoo7/go-gecko/v3/types"
+)
+
+// PriceService wraps a Client with symbol/contract-address resolution,
+// coalescing of overlapping SimplePrice calls, and short-lived result
+// caching, so callers hitting /simple/price in a loop don't burn quota.
+type PriceService struct {
+	client *Client
+
+	listTTL time.Duration
+
+	mu         sync.RWMutex
+	list       *types.CoinList
+	fetchedAt  time.Time
+	bySymbol   map[string][]types.CoinItem
+	byContract map[string]map[string]string // platform -> lowercased contract address -> id
+	sf         singleflight.Group
+
+	priceTTL   time.Duration
+	priceMu    sync.Mutex
+	priceCache map[string]priceCacheEntry
+}
+
+type priceCacheEntry struct {
+	price     float32
+	expiresAt time.Time
+}
+
+// NewPriceService creates a PriceService backed by client. listTTL controls
+// how long the /coins/list symbol/contract resolution table is cached;
+// priceTTL controls how long individual (id, vs_currency) prices are cached.
+func NewPriceService(client *Client, listTTL, priceTTL time.Duration) *PriceService {
+	return &PriceService{
+		client:     client,
+		listTTL:    listTTL,
+		priceTTL:   priceTTL,
+		priceCache: make(map[string]priceCacheEntry),
+	}
+}
+
+// resolveList lazily (re)fetches /coins/list?include_platform=true once
+// listTTL has elapsed, coalescing concurrent refreshes into one request.
+func (s *PriceService) resolveList(ctx context.Context) error {
+	s.mu.RLock()
+	fresh := s.list != nil && time.Since(s.fetchedAt) < s.listTTL
+	s.mu.RUnlock()
+	if fresh {
+		return nil
+	}
+
+	v, err, _ := s.sf.Do("coins-list", func() (interface{}, error) {
+		return s.client.CoinsList(ctx, true)
+	})
+	if err != nil {
+		return err
+	}
+	list := v.(*types.CoinList)
+
+	bySymbol := make(map[string][]types.CoinItem, len(*list))
+	byContract := make(map[string]map[string]string)
+	for _, item := range *list {
+		sym := strings.ToLower(item.Symbol)
+		bySymbol[sym] = append(bySymbol[sym], item)
+		for platform, addr := range item.Platforms {
+			if addr == "" {
+				continue
+			}
+			m, ok := byContract[platform]
+			if !ok {
+				m = make(map[string]string)
+				byContract[platform] = m
+			}
+			m[strings.ToLower(addr)] = item.ID
+		}
+	}
+
+	s.mu.Lock()
+	s.list = list
+	s.fetchedAt = time.Now()
+	s.bySymbol = bySymbol
+	s.byContract = byContract
+	s.mu.Unlock()
+	return nil
+}
+
+// PricesBySymbol resolves token symbols (e.g. "ETH") to CoinGecko ids via the
+// cached /coins/list, then returns the same map shape as Client.SimplePrice.
+func (s *PriceService) PricesBySymbol(ctx context.Context, symbols []string, vsCurrencies []string) (*map[string]map[string]float32, error) {
+	if err := s.resolveList(ctx); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	ids := make([]string, 0, len(symbols))
+	var unknown, ambiguous []string
+	for _, sym := range symbols {
+		items := s.bySymbol[strings.ToLower(sym)]
+		switch len(items) {
+		case 0:
+			unknown = append(unknown, sym)
+		case 1:
+			ids = append(ids, items[0].ID)
+		default:
+			ambiguous = append(ambiguous, sym)
+		}
+	}
+	s.mu.RUnlock()
+	if len(unknown) != 0 {
+		return nil, fmt.Errorf("coingecko: unresolved symbols: %s", strings.Join(unknown, ", "))
+	}
+	if len(ambiguous) != 0 {
+		return nil, fmt.Errorf("coingecko: ambiguous symbols, matched by more than one coin: %s", strings.Join(ambiguous, ", "))
+	}
+
+	return s.SimplePrice(ctx, ids, vsCurrencies)
+}
+
+// PriceByContract resolves a token contract address on platform (e.g.
+// "ethereum") to a CoinGecko id and returns its price.
+func (s *PriceService) PriceByContract(ctx context.Context, platform, address string, vsCurrencies []string) (*map[string]map[string]float32, error) {
+	if err := s.resolveList(ctx); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	id, ok := s.byContract[platform][strings.ToLower(address)]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("coingecko: no coin found for contract %s on %s", address, platform)
+	}
+
+	return s.SimplePrice(ctx, []string{id}, vsCurrencies)
+}
+
+// SimplePrice mirrors Client.SimplePrice but serves cached (id, vs_currency)
+// pairs directly and coalesces concurrent requests for the same missing
+// id/currency set into a single upstream call via singleflight.
+func (s *PriceService) SimplePrice(ctx context.Context, ids []string, vsCurrencies []string) (*map[string]map[string]float32, error) {
+	result := make(map[string]map[string]float32)
+
+	s.priceMu.Lock()
+	now := time.Now()
+	var missing []string
+	for _, id := range ids {
+		hit := true
+		for _, vc := range vsCurrencies {
+			if entry, ok := s.priceCache[priceCacheKey(id, vc)]; !ok || now.After(entry.expiresAt) {
+				hit = false
+				break
+			}
+		}
+		if !hit {
+			missing = append(missing, id)
+			continue
+		}
+		result[id] = make(map[string]float32, len(vsCurrencies))
+		for _, vc := range vsCurrencies {
+			result[id][vc] = s.priceCache[priceCacheKey(id, vc)].price
+		}
+	}
+	s.priceMu.Unlock()
+
+	if len(missing) == 0 {
+		return &result, nil
+	}
+
+	sortedMissing := append([]string(nil), missing...)
+	sort.Strings(sortedMissing)
+	sfKey := strings.Join(sortedMissing, ",") + "|" + currencySetKey(vsCurrencies)
+
+	v, err, _ := s.sf.Do(sfKey, func() (interface{}, error) {
+		return s.client.SimplePrice(ctx, missing, vsCurrencies)
+	})
+	if err != nil {
+		return nil, err
+	}
+	fetched := v.(*map[string]map[string]float32)
+
+	expiresAt := time.Now().Add(s.priceTTL)
+	s.priceMu.Lock()
+	for id, byCurrency := range *fetched {
+		result[id] = byCurrency
+		for vc, price := range byCurrency {
+			s.priceCache[priceCacheKey(id, vc)] = priceCacheEntry{price: price, expiresAt: expiresAt}
+		}
+	}
+	s.priceMu.Unlock()
+
+	return &result, nil
+}
+
+func priceCacheKey(id, vc string) string {
+	return id + "|" + vc
+}
@@ -0,0 +1,76 @@
+package coingecko
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPricesBySymbolAmbiguous(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/coins/list") {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`[{"id":"uniswap","symbol":"uni","name":"Uniswap"},{"id":"unicorn-token","symbol":"uni","name":"Unicorn Token"}]`))
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	client := NewClientWithURL(nil, srv.URL, "")
+	ps := NewPriceService(client, time.Hour, time.Minute)
+
+	if _, err := ps.PricesBySymbol(context.Background(), []string{"uni"}, []string{"usd"}); err == nil {
+		t.Fatal("expected an error for a symbol matched by more than one coin, got nil")
+	}
+}
+
+// TestSimplePriceOverlappingSets exercises two concurrent calls whose id
+// sets overlap (but aren't identical) on "ethereum": both must still see a
+// correct, complete result for every id they asked for.
+func TestSimplePriceOverlappingSets(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids := r.URL.Query().Get("ids")
+		resp := make(map[string]map[string]float32)
+		for _, id := range strings.Split(ids, ",") {
+			resp[id] = map[string]float32{"usd": 1}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+
+	client := NewClientWithURL(nil, srv.URL, "")
+	ps := NewPriceService(client, time.Hour, time.Hour)
+
+	var wg sync.WaitGroup
+	results := make([]*map[string]map[string]float32, 2)
+	errs := make([]error, 2)
+	sets := [][]string{{"bitcoin", "ethereum"}, {"ethereum", "litecoin"}}
+
+	wg.Add(2)
+	for i := range sets {
+		i := i
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = ps.SimplePrice(context.Background(), sets[i], []string{"usd"})
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("SimplePrice(%v) returned error: %v", sets[i], err)
+		}
+		for _, id := range sets[i] {
+			if _, ok := (*results[i])[id]; !ok {
+				t.Fatalf("SimplePrice(%v) result missing id %q", sets[i], id)
+			}
+		}
+	}
+}
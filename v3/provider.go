@@ -0,0 +1,7 @@
+package coingecko
+
+import "github.com/superoo7/go-gecko/provider"
+
+// Client satisfies provider.PriceProvider so it can be wired into
+// provider.Fallback alongside other backends (e.g. provider/coinmarketcap).
+var _ provider.PriceProvider = (*Client)(nil)
@@ -0,0 +1,381 @@
+package coingecko
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/superoo7/go-gecko/format"
+	"github.com/superoo7/go-gecko/v3/types"
+)
+
+// PriceUpdate is emitted by SubscribePrices whenever a subscribed id's price
+// in a given currency changes, and once when the subscription group first
+// sees a price for that id and currency.
+type PriceUpdate struct {
+	ID       string
+	Currency string
+	Price    float32
+}
+
+// SubscribeOption configures a price/market subscription.
+type SubscribeOption func(*subscribeConfig)
+
+type subscribeConfig struct {
+	minDelta float32
+}
+
+// WithMinDelta sets how far a price must move, in absolute terms, before
+// SubscribePrices emits an update, to reduce noise from sub-cent jitter: a
+// change of delta or less is not emitted. Defaults to 0 (emit on any change).
+func WithMinDelta(delta float32) SubscribeOption {
+	return func(c *subscribeConfig) { c.minDelta = delta }
+}
+
+// SubscribePrices polls /simple/price on interval and returns a channel of
+// updates plus an unsubscribe func. Subscribers that share the same
+// vs_currency set are batched behind a single ticker, and their ids are
+// deduplicated into one upstream request per tick. The channel is closed and
+// the subscription torn down when ctx is cancelled or unsubscribe is called.
+// A subscriber that joins an existing group is polled at that group's
+// interval; its own interval argument is then ignored.
+func (c *Client) SubscribePrices(ctx context.Context, ids []string, vsCurrencies []string, interval time.Duration, opts ...SubscribeOption) (<-chan PriceUpdate, func()) {
+	cfg := subscribeConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	group := c.priceSubGroup(vsCurrencies, interval)
+	sub := group.addSubscriber(ids, cfg.minDelta)
+
+	unsubscribe := func() { group.removeSubscriber(sub.id) }
+	go func() {
+		<-ctx.Done()
+		unsubscribe()
+	}()
+
+	return sub.ch, unsubscribe
+}
+
+// SubscribeMarkets polls /coins/markets on interval, batching subscribers
+// that share the same vs_currency/sparkline/priceChangePercentage settings
+// into one upstream request per tick, and fans out the full snapshot to
+// every subscriber so terminal-UI callers can render live tables. A
+// subscriber that joins an existing group is polled at that group's
+// interval; its own interval argument is then ignored.
+func (c *Client) SubscribeMarkets(ctx context.Context, vsCurrency string, ids []string, sparkline bool, priceChangePercentage []string, interval time.Duration) (<-chan *types.CoinsMarket, func()) {
+	group := c.marketSubGroup(vsCurrency, sparkline, priceChangePercentage, interval)
+	sub := group.addSubscriber(ids)
+
+	unsubscribe := func() { group.removeSubscriber(sub.id) }
+	go func() {
+		<-ctx.Done()
+		unsubscribe()
+	}()
+
+	return sub.ch, unsubscribe
+}
+
+func currencySetKey(vsCurrencies []string) string {
+	cp := append([]string(nil), vsCurrencies...)
+	sort.Strings(cp)
+	return strings.Join(cp, ",")
+}
+
+// --- price subscription group ---
+
+type priceSubGroup struct {
+	client       *Client
+	key          string
+	vsCurrencies []string
+
+	mu          sync.Mutex
+	ticker      *time.Ticker
+	stop        chan struct{}
+	stopOnce    sync.Once
+	subscribers map[int]*priceSubscriber
+	nextID      int
+	last        map[string]map[string]float32
+}
+
+type priceSubscriber struct {
+	id       int
+	ids      map[string]struct{}
+	minDelta float32
+	ch       chan PriceUpdate
+}
+
+func (c *Client) priceSubGroup(vsCurrencies []string, interval time.Duration) *priceSubGroup {
+	key := currencySetKey(vsCurrencies)
+
+	c.subsMu.Lock()
+	defer c.subsMu.Unlock()
+	if c.priceSubGroups == nil {
+		c.priceSubGroups = make(map[string]*priceSubGroup)
+	}
+	if g, ok := c.priceSubGroups[key]; ok {
+		return g
+	}
+
+	g := &priceSubGroup{
+		client:       c,
+		key:          key,
+		vsCurrencies: vsCurrencies,
+		ticker:       time.NewTicker(interval),
+		stop:         make(chan struct{}),
+		subscribers:  make(map[int]*priceSubscriber),
+		last:         make(map[string]map[string]float32),
+	}
+	c.priceSubGroups[key] = g
+	go g.run()
+	return g
+}
+
+func (g *priceSubGroup) addSubscriber(ids []string, minDelta float32) *priceSubscriber {
+	idSet := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		idSet[id] = struct{}{}
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	sub := &priceSubscriber{id: g.nextID, ids: idSet, minDelta: minDelta, ch: make(chan PriceUpdate, 16)}
+	g.nextID++
+	g.subscribers[sub.id] = sub
+	return sub
+}
+
+// removeSubscriber drops subscriber id and, if that was the last one, stops
+// the polling goroutine and retires the group. Safe to call more than once
+// for the same id (e.g. both an explicit unsubscribe and a ctx-cancel
+// watcher racing each other).
+func (g *priceSubGroup) removeSubscriber(id int) {
+	g.mu.Lock()
+	sub, ok := g.subscribers[id]
+	if ok {
+		delete(g.subscribers, id)
+		close(sub.ch)
+	}
+	empty := len(g.subscribers) == 0
+	g.mu.Unlock()
+
+	if empty {
+		g.stopOnce.Do(func() {
+			close(g.stop)
+			g.client.subsMu.Lock()
+			if g.client.priceSubGroups[g.key] == g {
+				delete(g.client.priceSubGroups, g.key)
+			}
+			g.client.subsMu.Unlock()
+		})
+	}
+}
+
+func (g *priceSubGroup) run() {
+	defer g.ticker.Stop()
+	for {
+		select {
+		case <-g.stop:
+			return
+		case <-g.ticker.C:
+			g.poll()
+		}
+	}
+}
+
+func (g *priceSubGroup) poll() {
+	g.mu.Lock()
+	if len(g.subscribers) == 0 {
+		g.mu.Unlock()
+		return
+	}
+	idSet := make(map[string]struct{})
+	subscribers := make([]*priceSubscriber, 0, len(g.subscribers))
+	for _, sub := range g.subscribers {
+		subscribers = append(subscribers, sub)
+		for id := range sub.ids {
+			idSet[id] = struct{}{}
+		}
+	}
+	ids := make([]string, 0, len(idSet))
+	for id := range idSet {
+		ids = append(ids, id)
+	}
+	vsCurrencies := g.vsCurrencies
+	g.mu.Unlock()
+
+	prices, err := g.client.SimplePrice(context.Background(), ids, vsCurrencies)
+	if err != nil {
+		// Best-effort polling: skip this tick and try again on the next one.
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for id, byCurrency := range *prices {
+		prev := g.last[id]
+		for currency, price := range byCurrency {
+			prevPrice, had := prev[currency]
+			delta := price - prevPrice
+			if delta < 0 {
+				delta = -delta
+			}
+			for _, sub := range subscribers {
+				if _, want := sub.ids[id]; !want {
+					continue
+				}
+				if had && delta <= sub.minDelta {
+					continue
+				}
+				select {
+				case sub.ch <- PriceUpdate{ID: id, Currency: currency, Price: price}:
+				default:
+				}
+			}
+		}
+		if g.last[id] == nil {
+			g.last[id] = make(map[string]float32, len(byCurrency))
+		}
+		for currency, price := range byCurrency {
+			g.last[id][currency] = price
+		}
+	}
+}
+
+// --- markets subscription group ---
+
+type marketSubGroup struct {
+	client                *Client
+	key                   string
+	vsCurrency            string
+	sparkline             bool
+	priceChangePercentage []string
+
+	mu          sync.Mutex
+	ticker      *time.Ticker
+	stop        chan struct{}
+	stopOnce    sync.Once
+	subscribers map[int]*marketSubscriber
+	nextID      int
+}
+
+type marketSubscriber struct {
+	id  int
+	ids map[string]struct{}
+	ch  chan *types.CoinsMarket
+}
+
+func (c *Client) marketSubGroup(vsCurrency string, sparkline bool, priceChangePercentage []string, interval time.Duration) *marketSubGroup {
+	key := strings.ToLower(vsCurrency) + "|" + currencySetKey(priceChangePercentage) + "|" + format.Bool2String(sparkline)
+
+	c.subsMu.Lock()
+	defer c.subsMu.Unlock()
+	if c.marketSubGroups == nil {
+		c.marketSubGroups = make(map[string]*marketSubGroup)
+	}
+	if g, ok := c.marketSubGroups[key]; ok {
+		return g
+	}
+
+	g := &marketSubGroup{
+		client:                c,
+		key:                   key,
+		vsCurrency:            vsCurrency,
+		sparkline:             sparkline,
+		priceChangePercentage: priceChangePercentage,
+		ticker:                time.NewTicker(interval),
+		stop:                  make(chan struct{}),
+		subscribers:           make(map[int]*marketSubscriber),
+	}
+	c.marketSubGroups[key] = g
+	go g.run()
+	return g
+}
+
+func (g *marketSubGroup) addSubscriber(ids []string) *marketSubscriber {
+	idSet := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		idSet[id] = struct{}{}
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	sub := &marketSubscriber{id: g.nextID, ids: idSet, ch: make(chan *types.CoinsMarket, 4)}
+	g.nextID++
+	g.subscribers[sub.id] = sub
+	return sub
+}
+
+// removeSubscriber drops subscriber id and, if that was the last one, stops
+// the polling goroutine and retires the group. Safe to call more than once
+// for the same id (e.g. both an explicit unsubscribe and a ctx-cancel
+// watcher racing each other).
+func (g *marketSubGroup) removeSubscriber(id int) {
+	g.mu.Lock()
+	sub, ok := g.subscribers[id]
+	if ok {
+		delete(g.subscribers, id)
+		close(sub.ch)
+	}
+	empty := len(g.subscribers) == 0
+	g.mu.Unlock()
+
+	if empty {
+		g.stopOnce.Do(func() {
+			close(g.stop)
+			g.client.subsMu.Lock()
+			if g.client.marketSubGroups[g.key] == g {
+				delete(g.client.marketSubGroups, g.key)
+			}
+			g.client.subsMu.Unlock()
+		})
+	}
+}
+
+func (g *marketSubGroup) run() {
+	defer g.ticker.Stop()
+	for {
+		select {
+		case <-g.stop:
+			return
+		case <-g.ticker.C:
+			g.poll()
+		}
+	}
+}
+
+func (g *marketSubGroup) poll() {
+	g.mu.Lock()
+	if len(g.subscribers) == 0 {
+		g.mu.Unlock()
+		return
+	}
+	idSet := make(map[string]struct{})
+	subscribers := make([]*marketSubscriber, 0, len(g.subscribers))
+	for _, sub := range g.subscribers {
+		subscribers = append(subscribers, sub)
+		for id := range sub.ids {
+			idSet[id] = struct{}{}
+		}
+	}
+	ids := make([]string, 0, len(idSet))
+	for id := range idSet {
+		ids = append(ids, id)
+	}
+	vsCurrency, sparkline, priceChangePercentage := g.vsCurrency, g.sparkline, g.priceChangePercentage
+	g.mu.Unlock()
+
+	market, err := g.client.CoinsMarket(context.Background(), vsCurrency, ids, "", 0, 0, sparkline, priceChangePercentage)
+	if err != nil {
+		return
+	}
+
+	for _, sub := range subscribers {
+		select {
+		case sub.ch <- market:
+		default:
+		}
+	}
+}
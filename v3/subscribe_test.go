@@ -0,0 +1,89 @@
+package coingecko
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestPriceSubGroupDoubleUnsubscribeNoPanic covers the standard
+// ctx, cancel := context.WithCancel(...); defer cancel() idiom combined with
+// an explicit unsubscribe() call: both paths end up calling
+// removeSubscriber for the same id, and must not double-close g.stop.
+func TestPriceSubGroupDoubleUnsubscribeNoPanic(t *testing.T) {
+	c := NewClient(nil, "")
+	ctx, cancel := context.WithCancel(context.Background())
+
+	_, unsubscribe := c.SubscribePrices(ctx, []string{"bitcoin"}, []string{"usd"}, time.Hour)
+
+	unsubscribe()
+	cancel()
+
+	// Give the ctx-cancel watcher goroutine a chance to run its (now
+	// no-op) unsubscribe before the test exits.
+	time.Sleep(10 * time.Millisecond)
+}
+
+// TestPriceSubGroupResubscribeAfterDrain covers reconnecting with the same
+// vs_currency set after every subscriber has unsubscribed: the stale group
+// must not be reused, or the new subscriber's channel never receives
+// anything.
+func TestPriceSubGroupResubscribeAfterDrain(t *testing.T) {
+	c := NewClient(nil, "")
+
+	_, unsubscribe := c.SubscribePrices(context.Background(), []string{"bitcoin"}, []string{"usd"}, time.Millisecond)
+	unsubscribe()
+	time.Sleep(10 * time.Millisecond)
+
+	c.subsMu.Lock()
+	_, stale := c.priceSubGroups["usd"]
+	c.subsMu.Unlock()
+	if stale {
+		t.Fatal("drained price subscription group was not removed from Client.priceSubGroups")
+	}
+}
+
+func TestMarketSubGroupResubscribeAfterDrain(t *testing.T) {
+	c := NewClient(nil, "")
+
+	_, unsubscribe := c.SubscribeMarkets(context.Background(), "usd", []string{"bitcoin"}, false, nil, time.Millisecond)
+	unsubscribe()
+	time.Sleep(10 * time.Millisecond)
+
+	c.subsMu.Lock()
+	n := len(c.marketSubGroups)
+	c.subsMu.Unlock()
+	if n != 0 {
+		t.Fatalf("drained market subscription group was not removed, got %d remaining", n)
+	}
+}
+
+// TestMarketSubGroupPartialUnsubscribePrunesIDs covers unsubscribing one of
+// two subscribers in a group: the departing subscriber's ids must drop out
+// of the next poll's union instead of being polled forever.
+func TestMarketSubGroupPartialUnsubscribePrunesIDs(t *testing.T) {
+	c := NewClient(nil, "")
+
+	_, unsubBitcoin := c.SubscribeMarkets(context.Background(), "usd", []string{"bitcoin"}, false, nil, time.Hour)
+	group := c.marketSubGroup("usd", false, nil, time.Hour)
+	_, unsubEthereum := c.SubscribeMarkets(context.Background(), "usd", []string{"ethereum"}, false, nil, time.Hour)
+	defer unsubEthereum()
+
+	unsubBitcoin()
+
+	group.mu.Lock()
+	idSet := make(map[string]struct{})
+	for _, sub := range group.subscribers {
+		for id := range sub.ids {
+			idSet[id] = struct{}{}
+		}
+	}
+	group.mu.Unlock()
+
+	if _, ok := idSet["bitcoin"]; ok {
+		t.Fatal("expected bitcoin to be pruned from the group's id union after unsubscribing")
+	}
+	if _, ok := idSet["ethereum"]; !ok {
+		t.Fatal("expected ethereum to remain in the group's id union")
+	}
+}
@@ -0,0 +1,55 @@
+package coingecko
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ClientOption configures a Client
+type ClientOption func(*Client)
+
+// WithMinInterval sets the minimum delay between requests
+func WithMinInterval(d time.Duration) ClientOption {
+	return func(c *Client) { c.minInterval = d }
+}
+
+// WithMaxRetries sets how many times a 429/5xx is retried. Default 0.
+func WithMaxRetries(n int) ClientOption {
+	return func(c *Client) { c.maxRetries = n }
+}
+
+// WithBackoff overrides the retry delay strategy. Default DefaultBackoff.
+func WithBackoff(fn BackoffFunc) ClientOption {
+	return func(c *Client) { c.backoff = fn }
+}
+
+// BackoffFunc computes the delay before the next retry attempt
+type BackoffFunc func(attempt int, retryAfter time.Duration) time.Duration
+
+// DefaultBackoff honours Retry-After, else backs off exponentially with jitter
+func DefaultBackoff(attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return retryAfter
+	}
+	base := 500 * time.Millisecond * time.Duration(uint(1)<<uint(attempt))
+	return base + time.Duration(rand.Int63n(int64(base)/2+1))
+}
+
+// parseRetryAfter reads the Retry-After header (seconds or HTTP-date)
+func parseRetryAfter(h http.Header) time.Duration {
+	v := h.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
@@ -0,0 +1,39 @@
+package coingecko
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMakeReqRetriesAndReportsAttempt(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	client := NewClientWithURL(nil, srv.URL, "",
+		WithMaxRetries(2),
+		WithBackoff(func(attempt int, retryAfter time.Duration) time.Duration { return time.Millisecond }),
+	)
+
+	_, err := client.MakeReq(context.Background(), srv.URL+"/ping")
+	if err == nil {
+		t.Fatal("expected an error after retries are exhausted")
+	}
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		t.Fatalf("expected *APIError, got %T", err)
+	}
+	if apiErr.Attempt != 2 {
+		t.Fatalf("expected Attempt to report the final exhausted attempt (2), got %d", apiErr.Attempt)
+	}
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Fatalf("expected 1 initial try + 2 retries = 3 calls, got %d", got)
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/superoo7/go-gecko/format"
 	"github.com/superoo7/go-gecko/v3/types"
@@ -21,48 +23,74 @@ type Client struct {
 	httpClient *http.Client
 	apiKey     string
 	url        string
+
+	minInterval time.Duration
+	maxRetries  int
+	backoff     BackoffFunc
+
+	throttleMu sync.Mutex
+	lastReqAt  time.Time
+
+	subsMu          sync.Mutex
+	priceSubGroups  map[string]*priceSubGroup
+	marketSubGroups map[string]*marketSubGroup
+
+	cache       Cache
+	cachePolicy CachePolicy
 }
 
 // NewClient create new client object
-func NewClient(httpClient *http.Client, apiKey string) *Client {
+func NewClient(httpClient *http.Client, apiKey string, opts ...ClientOption) *Client {
 	url := baseURL
 	if apiKey != "" {
 		url = proURL
 	}
-	return NewClientWithURL(httpClient, url, apiKey)
+	return NewClientWithURL(httpClient, url, apiKey, opts...)
 }
 
-func NewClientWithURL(httpClient *http.Client, url, apiKey string) *Client {
+func NewClientWithURL(httpClient *http.Client, url, apiKey string, opts ...ClientOption) *Client {
 	if httpClient == nil {
 		httpClient = http.DefaultClient
 	}
 
-	return &Client{httpClient: httpClient, apiKey: apiKey, url: url}
+	c := &Client{httpClient: httpClient, apiKey: apiKey, url: url, backoff: DefaultBackoff}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 // helper
-// doReq HTTP client
-func doReq(req *http.Request, client *http.Client) ([]byte, error) {
+// doReq HTTP client. The *http.Response is returned (body already drained
+// and closed) so callers can inspect status/headers for conditional-GET and
+// caching support.
+func doReq(req *http.Request, client *http.Client) ([]byte, *http.Response, error) {
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer resp.Body.Close()
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
 	}
-	if 200 != resp.StatusCode {
-		if len(body) != 0 {
-			return nil, fmt.Errorf("%s", body)
-		} else {
-			return nil, fmt.Errorf(`{"status": {"error_code": %d}}`, resp.StatusCode)
+	if resp.StatusCode != 200 && resp.StatusCode != 304 {
+		return nil, resp, &APIError{
+			StatusCode: resp.StatusCode,
+			Body:       body,
+			RetryAfter: parseRetryAfter(resp.Header),
 		}
 	}
-	return body, nil
+	return body, resp, nil
 }
 
-// MakeReq HTTP request helper
+// MakeReq HTTP request helper. If the client was configured with
+// WithMinInterval, requests are serialized to respect that budget; if the
+// server responds 429/5xx, the request is retried up to WithMaxRetries times
+// using the configured backoff (WithBackoff), honouring Retry-After. If the
+// client was built with NewClientWithCache, fresh cached responses are
+// served without hitting the network, and stale-but-present ones are
+// refreshed with a conditional GET.
 func (c *Client) MakeReq(ctx context.Context, url string) ([]byte, error) {
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 
@@ -76,11 +104,78 @@ func (c *Client) MakeReq(ctx context.Context, url string) ([]byte, error) {
 		}
 	}
 
-	resp, err := doReq(req, c.httpClient)
-	if err != nil {
-		return nil, err
+	var ttl time.Duration
+	var cached *CacheEntry
+	if c.cache != nil {
+		ttl = c.cachePolicy(url)
+		if ttl != 0 {
+			if entry, ok := c.cache.Get(ctx, url); ok {
+				if ttl < 0 || time.Since(entry.StoredAt) < ttl {
+					return entry.Body, nil
+				}
+				cached = entry
+				if entry.ETag != "" {
+					req.Header.Set("If-None-Match", entry.ETag)
+				}
+				if entry.LastModified != "" {
+					req.Header.Set("If-Modified-Since", entry.LastModified)
+				}
+			}
+		}
 	}
-	return resp, err
+
+	var lastErr error
+	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+		c.throttle()
+
+		body, resp, err := doReq(req, c.httpClient)
+		if err == nil {
+			if resp.StatusCode == 304 && cached != nil {
+				cached.StoredAt = time.Now()
+				_ = c.cache.Set(ctx, url, cached)
+				return cached.Body, nil
+			}
+			if c.cache != nil && ttl != 0 {
+				_ = c.cache.Set(ctx, url, &CacheEntry{
+					Body:         body,
+					ETag:         resp.Header.Get("ETag"),
+					LastModified: resp.Header.Get("Last-Modified"),
+					StoredAt:     time.Now(),
+				})
+			}
+			return body, nil
+		}
+
+		apiErr, ok := err.(*APIError)
+		if ok {
+			apiErr.Attempt = attempt
+		}
+		if !ok || !apiErr.Retryable() || attempt == c.maxRetries {
+			return nil, err
+		}
+		lastErr = apiErr
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(c.backoff(attempt, apiErr.RetryAfter)):
+		}
+	}
+	return nil, lastErr
+}
+
+// throttle blocks until minInterval has elapsed since the previous request,
+// serializing callers so the client stays within a requests-per-minute budget.
+func (c *Client) throttle() {
+	if c.minInterval <= 0 {
+		return
+	}
+	c.throttleMu.Lock()
+	defer c.throttleMu.Unlock()
+	if wait := c.minInterval - time.Since(c.lastReqAt); wait > 0 {
+		time.Sleep(wait)
+	}
+	c.lastReqAt = time.Now()
 }
 
 // API
@@ -316,14 +411,76 @@ func (c *Client) CoinsIDMarketChart(ctx context.Context, id string, vs_currency
 
 // CoinsIDStatusUpdates
 
-// CoinsIDContractAddress https://api.coingecko.com/api/v3/coins/{id}/contract/{contract_address}
-// func CoinsIDContractAddress(id string, address string) (nil, error) {
-// 	url := fmt.Sprintf("%s/coins/%s/contract/%s", c.url, id, address)
-// 	resp, err := request.MakeReq(url)
-// 	if err != nil {
-// 		return nil, err
-// 	}
-// }
+// CoinsIDContractAddress /coins/{id}/contract/{contract_address}
+func (c *Client) CoinsIDContractAddress(ctx context.Context, id string, address string) (*types.CoinsID, error) {
+	if len(id) == 0 || len(address) == 0 {
+		return nil, fmt.Errorf("id and contract address is required")
+	}
+	url := fmt.Sprintf("%s/coins/%s/contract/%s", c.url, id, address)
+	resp, err := c.MakeReq(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	var data *types.CoinsID
+	err = json.Unmarshal(resp, &data)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// CoinsIDContractMarketChart /coins/{id}/contract/{contract_address}/market_chart?vs_currency={usd, eur, jpy, etc.}&days={1,14,30,max}
+func (c *Client) CoinsIDContractMarketChart(ctx context.Context, id string, address string, vsCurrency string, days string) (*types.CoinsIDMarketChart, error) {
+	if len(id) == 0 || len(address) == 0 || len(vsCurrency) == 0 || len(days) == 0 {
+		return nil, fmt.Errorf("id, contract address, vs_currency, and days is required")
+	}
+
+	params := url.Values{}
+	params.Add("vs_currency", vsCurrency)
+	params.Add("days", days)
+
+	url := fmt.Sprintf("%s/coins/%s/contract/%s/market_chart?%s", c.url, id, address, params.Encode())
+	resp, err := c.MakeReq(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	m := types.CoinsIDMarketChart{}
+	err = json.Unmarshal(resp, &m)
+	if err != nil {
+		return &m, err
+	}
+	return &m, nil
+}
+
+// CoinsIDMarketChartRange /coins/{id}/market_chart/range?vs_currency={usd, eur, jpy, etc.}&from={unix}&to={unix}
+func (c *Client) CoinsIDMarketChartRange(ctx context.Context, id string, vsCurrency string, from time.Time, to time.Time) (*types.CoinsIDMarketChart, error) {
+	if len(id) == 0 || len(vsCurrency) == 0 {
+		return nil, fmt.Errorf("id and vs_currency is required")
+	}
+	if !to.After(from) {
+		return nil, fmt.Errorf("to must be after from")
+	}
+
+	params := url.Values{}
+	params.Add("vs_currency", vsCurrency)
+	params.Add("from", format.Int2String(int(from.Unix())))
+	params.Add("to", format.Int2String(int(to.Unix())))
+
+	url := fmt.Sprintf("%s/coins/%s/market_chart/range?%s", c.url, id, params.Encode())
+	resp, err := c.MakeReq(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	m := types.CoinsIDMarketChart{}
+	err = json.Unmarshal(resp, &m)
+	if err != nil {
+		return &m, err
+	}
+	return &m, nil
+}
 
 // EventsCountries https://api.coingecko.com/api/v3/events/countries
 func (c *Client) EventsCountries(ctx context.Context) ([]types.EventCountryItem, error) {